@@ -2,19 +2,53 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
 	"sync"
 
 	"github.com/golang/glog"
 	"github.com/rockstardevs/parser/ofx"
+	"github.com/rockstardevs/parser/ofx/export"
 )
 
 var (
 	numWorkers = flag.Int("num_workers", 3, "number of workers to process files concurrently.")
 	jobsBuffer = flag.Int("jobs_buffer_size", 10, "numbers of jobs to buffer at a time.")
+
+	format       = flag.String("format", "json", "output format: json, csv, ledger, or beancount.")
+	account      = flag.String("account", "Assets:Unknown", "ledger/beancount account the statement represents.")
+	currency     = flag.String("currency", "USD", "currency code for beancount amounts.")
+	accountRules = flag.String("account_rules", "", "path to a JSON rules file mapping transactions to accounts (ledger/beancount only).")
 )
 
-// processFile processes an individual input file.
-func processFile(index int, jobs <-chan string, wg *sync.WaitGroup) {
+// newWriter builds the export.Writer for *format, writing to w.
+func newWriter(w *os.File) (export.Writer, error) {
+	var mapper export.AccountMapper
+	if *accountRules != "" {
+		ruleMapper, err := export.LoadRuleMapper(*accountRules)
+		if err != nil {
+			return nil, err
+		}
+		mapper = ruleMapper
+	}
+	switch *format {
+	case "json":
+		return export.NewJSONWriter(w), nil
+	case "csv":
+		return export.NewCSVWriter(w), nil
+	case "ledger":
+		return export.NewLedgerWriter(w, *account, mapper), nil
+	case "beancount":
+		return export.NewBeancountWriter(w, *account, *currency, mapper), nil
+	default:
+		return nil, fmt.Errorf("unknown -format %q", *format)
+	}
+}
+
+// processFile processes an individual input file, writing it out via writer.
+// Access to writer is serialized with outMu, since os.Stdout is shared
+// across all workers.
+func processFile(index int, jobs <-chan string, writer export.Writer, outMu *sync.Mutex, wg *sync.WaitGroup) {
 	glog.Infof("started worker %d", index)
 	defer wg.Done()
 	for filename := range jobs {
@@ -22,9 +56,13 @@ func processFile(index int, jobs <-chan string, wg *sync.WaitGroup) {
 		document, err := ofx.NewDocumentFromXML(filename)
 		if err != nil {
 			glog.Errorf("worker %d: error processing %s - %s", index, filename, err)
+			continue
+		}
+		outMu.Lock()
+		if err := writer.Write(document); err != nil {
+			glog.Errorf("worker %d: error writing %s - %s", index, filename, err)
 		}
-		// TODO: do something with the parsed document.
-		glog.Infof("%v", document)
+		outMu.Unlock()
 	}
 	glog.Infof("shutting down worker %d", index)
 }
@@ -32,11 +70,17 @@ func processFile(index int, jobs <-chan string, wg *sync.WaitGroup) {
 func main() {
 	flag.Parse()
 
+	writer, err := newWriter(os.Stdout)
+	if err != nil {
+		glog.Exitf("error setting up %s writer: %s", *format, err)
+	}
+
 	// Start workers
 	var wg sync.WaitGroup
+	var outMu sync.Mutex
 	jobsChan := make(chan string, *jobsBuffer)
 	for i := 0; i <= *numWorkers; i++ {
-		go processFile(i, jobsChan, &wg)
+		go processFile(i, jobsChan, writer, &outMu, &wg)
 		wg.Add(1)
 	}
 
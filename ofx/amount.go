@@ -0,0 +1,112 @@
+package ofx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Amount is an OFX monetary value, e.g. TRNAMT or BALAMT. It is backed by a
+// fixed-point (unscaled int64, decimal scale) representation rather than
+// float64, so parsing and re-marshaling a value like "19.99" can't drift
+// from binary floating-point rounding, and preserves the exact string seen
+// on the wire for re-marshaling besides. Float64 is provided for callers
+// that only need an approximate value for arithmetic or display.
+type Amount struct {
+	raw      string
+	unscaled int64 // the amount's digits, sign included, with the decimal point removed.
+	scale    int   // how many of unscaled's trailing digits are after the decimal point.
+}
+
+// ParseAmount parses s, an OFX decimal amount such as "-12.34".
+func ParseAmount(s string) (Amount, error) {
+	trimmed := strings.TrimSpace(s)
+	digits := trimmed
+	negative := false
+	switch {
+	case strings.HasPrefix(digits, "-"):
+		negative = true
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" {
+		return Amount{}, fmt.Errorf("ofx: malformed amount %q", s)
+	}
+	unscaled, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Amount{}, fmt.Errorf("ofx: malformed amount %q: %w", s, err)
+	}
+	if negative {
+		unscaled = -unscaled
+	}
+	scale := 0
+	if hasFrac {
+		scale = len(fracPart)
+	}
+	return Amount{raw: s, unscaled: unscaled, scale: scale}, nil
+}
+
+// Float64 returns the amount's approximate numeric value. Prefer String or
+// direct comparison of two Amounts parsed from the same scale where exact
+// precision matters; float64 can't represent every decimal amount exactly.
+func (a Amount) Float64() float64 {
+	return float64(a.unscaled) / math.Pow10(a.scale)
+}
+
+// String returns the original wire string.
+func (a Amount) String() string {
+	return a.raw
+}
+
+// decimalString renders the amount's exact fixed-point value as a decimal
+// string, independent of how it was originally formatted on the wire (e.g.
+// without a leading "+", with a consistent number of fractional digits).
+func (a Amount) decimalString() string {
+	negative := a.unscaled < 0
+	u := a.unscaled
+	if negative {
+		u = -u
+	}
+	digits := strconv.FormatInt(u, 10)
+	if a.scale > 0 {
+		for len(digits) <= a.scale {
+			digits = "0" + digits
+		}
+		digits = digits[:len(digits)-a.scale] + "." + digits[len(digits)-a.scale:]
+	}
+	if negative {
+		digits = "-" + digits
+	}
+	return digits
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (a *Amount) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := decoder.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, writing back the original wire
+// string rather than a reformatted one.
+func (a Amount) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(a.raw, start)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the amount as the exact
+// decimal number it parsed rather than a float64-rounded approximation or
+// its internal fields.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.decimalString()), nil
+}
@@ -0,0 +1,96 @@
+package ofx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmountPreservesExactDigitsBeyondFloat64(t *testing.T) {
+	a, err := ParseAmount("19.99")
+	if err != nil {
+		t.Fatalf("ParseAmount: %v", err)
+	}
+	// 19.99 has no exact float64 representation; decimalString must still
+	// round-trip the exact digits that were parsed, not a rounded one.
+	if got, want := a.decimalString(), "19.99"; got != want {
+		t.Errorf("decimalString() = %q, want %q", got, want)
+	}
+}
+
+func TestParseAmountNegativeAndPositiveSign(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"-12.34", "-12.34"},
+		{"+12.34", "12.34"},
+		{"12.34", "12.34"},
+		{"-0.05", "-0.05"},
+	}
+	for _, tt := range tests {
+		a, err := ParseAmount(tt.in)
+		if err != nil {
+			t.Fatalf("ParseAmount(%q): %v", tt.in, err)
+		}
+		if got := a.decimalString(); got != tt.want {
+			t.Errorf("ParseAmount(%q).decimalString() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAmountNoFractionalPart(t *testing.T) {
+	a, err := ParseAmount("100")
+	if err != nil {
+		t.Fatalf("ParseAmount: %v", err)
+	}
+	if got, want := a.decimalString(), "100"; got != want {
+		t.Errorf("decimalString() = %q, want %q", got, want)
+	}
+	if got, want := a.Float64(), 100.0; got != want {
+		t.Errorf("Float64() = %v, want %v", got, want)
+	}
+}
+
+func TestParseAmountLeadingZeroInFraction(t *testing.T) {
+	a, err := ParseAmount("-1.05")
+	if err != nil {
+		t.Fatalf("ParseAmount: %v", err)
+	}
+	if got, want := a.decimalString(), "-1.05"; got != want {
+		t.Errorf("decimalString() = %q, want %q", got, want)
+	}
+}
+
+func TestParseAmountRejectsMalformedInput(t *testing.T) {
+	for _, s := range []string{"", "-", "+", "abc", "1.2.3"} {
+		if _, err := ParseAmount(s); err == nil {
+			t.Errorf("ParseAmount(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+func TestAmountStringPreservesWireFormatting(t *testing.T) {
+	a, err := ParseAmount("+012.3400")
+	if err != nil {
+		t.Fatalf("ParseAmount: %v", err)
+	}
+	if got, want := a.String(), "+012.3400"; got != want {
+		t.Errorf("String() = %q, want the untouched wire string %q", got, want)
+	}
+	if got, want := a.decimalString(), "12.3400"; got != want {
+		t.Errorf("decimalString() = %q, want %q", got, want)
+	}
+}
+
+func TestAmountMarshalJSONEmitsExactDecimalNotFloat64Rounding(t *testing.T) {
+	a, err := ParseAmount("19.999999999999998")
+	if err != nil {
+		t.Fatalf("ParseAmount: %v", err)
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got, want := string(data), "19.999999999999998"; got != want {
+		t.Errorf("MarshalJSON = %s, want the exact decimal %s (not a float64-rounded value)", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+// Package client builds and sends OFX statement requests to financial
+// institution servers and parses their responses with the ofx package.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+// dateLayout is the OFX datetime grammar used for DTCLIENT/DTSTART/DTEND.
+const dateLayout = "20060102150405"
+
+// Client holds the connection and sign-on details needed to request
+// statements from a financial institution's OFX server.
+type Client struct {
+	URL        string
+	FID        string
+	Org        string
+	BankID     string
+	UserID     string
+	UserPass   string
+	AppID      string
+	AppVer     string
+	OFXVersion string
+	HTTPClient *http.Client
+}
+
+// httpClient returns c.HTTPClient, or http.DefaultClient if none was set.
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// isXML reports whether c.OFXVersion selects OFX 2.x XML framing rather
+// than OFX/QFX 1.x SGML framing.
+func (c *Client) isXML() bool {
+	version, err := strconv.Atoi(c.OFXVersion)
+	return err == nil && version >= 200
+}
+
+// signOnRequest builds the SIGNONMSGSRQV1 every OFX request carries.
+func (c *Client) signOnRequest() signOnMessageSetRequest {
+	return signOnMessageSetRequest{
+		SONRQ: signOnRequestBody{
+			DTClient: time.Now().Format(dateLayout),
+			UserID:   c.UserID,
+			UserPass: c.UserPass,
+			Language: "ENG",
+			FI:       fiRequest{Org: c.Org, FID: c.FID},
+			AppID:    c.AppID,
+			AppVer:   c.AppVer,
+		},
+	}
+}
+
+// marshalRequest renders v, the OFX request body, as a complete OFX/QFX
+// document: the OFXHEADER:100 SGML preamble and VERSION when
+// c.OFXVersion selects 1.x framing, or the <?xml?>/<?OFX?> prolog when it
+// selects 2.x XML framing.
+func (c *Client) marshalRequest(v interface{}) ([]byte, error) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if c.isXML() {
+		fmt.Fprintf(&buf, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+		fmt.Fprintf(&buf, "<?OFX OFXHEADER=\"200\" VERSION=\"%s\" SECURITY=\"NONE\" OLDFILEUID=\"NONE\" NEWFILEUID=\"NONE\"?>\n", c.OFXVersion)
+	} else {
+		fmt.Fprintf(&buf, "OFXHEADER:100\nDATA:OFXSGML\nVERSION:%s\nSECURITY:NONE\nENCODING:USASCII\nCHARSET:NONE\nCOMPRESSION:NONE\nOLDFILEUID:NONE\nNEWFILEUID:NONE\n\n", c.OFXVersion)
+	}
+	buf.Write(body)
+	return buf.Bytes(), nil
+}
+
+// post sends body to c.URL as an OFX request and parses the response.
+func (c *Client) post(ctx context.Context, body []byte) (*ofx.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-ofx")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ofx/client: server returned status %d", resp.StatusCode)
+	}
+	return ofx.NewDocumentFromBytes(respBody)
+}
+
+// newTrnUID returns a client-unique transaction UID suitable for TRNUID,
+// which OFX servers use to detect duplicate requests.
+func newTrnUID() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return time.Now().Format(dateLayout)
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// ofxDate formats t per the OFX datetime grammar, or returns "" for a zero
+// Time so optional DTSTART/DTEND fields are omitted.
+func ofxDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
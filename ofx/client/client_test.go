@@ -0,0 +1,123 @@
+package client
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalRequestSGMLFraming(t *testing.T) {
+	c := &Client{OFXVersion: "102"}
+	body, err := c.marshalRequest(signOnMessageSetRequest{})
+	if err != nil {
+		t.Fatalf("marshalRequest: %v", err)
+	}
+	got := string(body)
+	if !strings.HasPrefix(got, "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n") {
+		t.Errorf("marshalRequest(1.x) = %q, want an OFXHEADER:100 SGML preamble", got)
+	}
+	if strings.Contains(got, "<?xml") {
+		t.Errorf("marshalRequest(1.x) = %q, should not contain an XML prolog", got)
+	}
+}
+
+func TestMarshalRequestXMLFraming(t *testing.T) {
+	c := &Client{OFXVersion: "203"}
+	body, err := c.marshalRequest(signOnMessageSetRequest{})
+	if err != nil {
+		t.Fatalf("marshalRequest: %v", err)
+	}
+	got := string(body)
+	if !strings.HasPrefix(got, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<?OFX OFXHEADER=\"200\" VERSION=\"203\"") {
+		t.Errorf("marshalRequest(2.x) = %q, want an <?xml?>/<?OFX?> prolog with VERSION=203", got)
+	}
+}
+
+func TestIsXML(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"102", false},
+		{"151", false},
+		{"200", true},
+		{"220", true},
+		{"", false},
+		{"not-a-number", false},
+	}
+	for _, tt := range tests {
+		c := &Client{OFXVersion: tt.version}
+		if got := c.isXML(); got != tt.want {
+			t.Errorf("Client{OFXVersion: %q}.isXML() = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestOfxDateZeroIsEmpty(t *testing.T) {
+	if got := ofxDate(time.Time{}); got != "" {
+		t.Errorf("ofxDate(zero) = %q, want empty string", got)
+	}
+}
+
+func TestOfxDateFormatsPerOFXGrammar(t *testing.T) {
+	tm := time.Date(2023, time.April, 5, 9, 30, 15, 0, time.UTC)
+	if got, want := ofxDate(tm), "20230405093015"; got != want {
+		t.Errorf("ofxDate(%v) = %q, want %q", tm, got, want)
+	}
+}
+
+func TestYesNoMarshalsAsYOrN(t *testing.T) {
+	tests := []struct {
+		in   yesNo
+		want string
+	}{
+		{true, "<INCLUDE>Y</INCLUDE>"},
+		{false, "<INCLUDE>N</INCLUDE>"},
+	}
+	for _, tt := range tests {
+		data, err := xml.Marshal(incPosRequest{Include: tt.in})
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", tt.in, err)
+		}
+		if got := string(data); !strings.Contains(got, tt.want) {
+			t.Errorf("Marshal(incPosRequest{Include: %v}) = %q, want it to contain %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRequestStatementUsesYNForBooleanFields(t *testing.T) {
+	c := &Client{OFXVersion: "102"}
+	body, err := c.marshalRequest(statementRequestDocument{
+		Bank: bankMessageSetRequest{
+			STMTTRNRQ: statementTrnRequest{
+				STMTRQ: statementRequest{
+					IncTran:        incTranRequest{Include: true},
+					IncludePending: false,
+					IncTranImg:     true,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshalRequest: %v", err)
+	}
+	got := string(body)
+	if strings.Contains(got, "true") || strings.Contains(got, "false") {
+		t.Errorf("marshalRequest body %q contains Go bool text instead of Y/N", got)
+	}
+	if !strings.Contains(got, "<INCLUDE>Y</INCLUDE>") || !strings.Contains(got, "<INCLUDEPENDING>N</INCLUDEPENDING>") || !strings.Contains(got, "<INCTRANIMG>Y</INCTRANIMG>") {
+		t.Errorf("marshalRequest body = %q, want Y/N literals for every boolean field", got)
+	}
+}
+
+func TestNewTrnUIDIsUniqueAndNonEmpty(t *testing.T) {
+	a := newTrnUID()
+	b := newTrnUID()
+	if a == "" || b == "" {
+		t.Fatal("newTrnUID() returned an empty string")
+	}
+	if a == b {
+		t.Errorf("newTrnUID() returned the same value twice: %q", a)
+	}
+}
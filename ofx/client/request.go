@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"time"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+type signOnMessageSetRequest struct {
+	SONRQ signOnRequestBody `xml:"SONRQ"`
+}
+
+type signOnRequestBody struct {
+	DTClient string    `xml:"DTCLIENT"`
+	UserID   string    `xml:"USERID"`
+	UserPass string    `xml:"USERPASS"`
+	Language string    `xml:"LANGUAGE"`
+	FI       fiRequest `xml:"FI"`
+	AppID    string    `xml:"APPID"`
+	AppVer   string    `xml:"APPVER"`
+}
+
+type fiRequest struct {
+	Org string `xml:"ORG"`
+	FID string `xml:"FID,omitempty"`
+}
+
+// yesNo is an OFX boolean aggregate (e.g. INCLUDE, INCLUDEPENDING, INCBAL),
+// which the spec requires to be rendered as the literal "Y" or "N" rather
+// than encoding/xml's default "true"/"false" text.
+type yesNo bool
+
+// MarshalXML implements xml.Marshaler.
+func (b yesNo) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := "N"
+	if b {
+		v = "Y"
+	}
+	return e.EncodeElement(v, start)
+}
+
+// BankAccount identifies the checking/savings account to request a
+// statement for.
+type BankAccount struct {
+	BankID      string
+	AccountID   string
+	AccountType string
+}
+
+// StatementOptions configures a RequestStatement call.
+type StatementOptions struct {
+	// Start and End bound the transactions requested via INCTRAN. Either
+	// may be left zero to omit the corresponding DTSTART/DTEND.
+	Start, End time.Time
+	// IncludeTransactions sets INCTRAN's INCLUDE; false asks the server
+	// for balances only.
+	IncludeTransactions bool
+	// IncludePending sets INCLUDEPENDING.
+	IncludePending bool
+	// IncludeTranImage sets INCTRANIMG.
+	IncludeTranImage bool
+}
+
+type bankMessageSetRequest struct {
+	STMTTRNRQ statementTrnRequest `xml:"STMTTRNRQ"`
+}
+
+type statementTrnRequest struct {
+	TrnUID string           `xml:"TRNUID"`
+	STMTRQ statementRequest `xml:"STMTRQ"`
+}
+
+type statementRequest struct {
+	BankAcctFrom   bankAccountFrom `xml:"BANKACCTFROM"`
+	IncTran        incTranRequest  `xml:"INCTRAN"`
+	IncludePending yesNo           `xml:"INCLUDEPENDING"`
+	IncTranImg     yesNo           `xml:"INCTRANIMG"`
+}
+
+type bankAccountFrom struct {
+	BankID   string `xml:"BANKID"`
+	AcctID   string `xml:"ACCTID"`
+	AcctType string `xml:"ACCTTYPE"`
+}
+
+type incTranRequest struct {
+	DtStart string `xml:"DTSTART,omitempty"`
+	DtEnd   string `xml:"DTEND,omitempty"`
+	Include yesNo  `xml:"INCLUDE"`
+}
+
+type statementRequestDocument struct {
+	XMLName xml.Name                `xml:"OFX"`
+	SignOn  signOnMessageSetRequest `xml:"SIGNONMSGSRQV1"`
+	Bank    bankMessageSetRequest   `xml:"BANKMSGSRQV1"`
+}
+
+// RequestStatement requests a checking/savings account statement for acct
+// and parses the server's response into an ofx.Document.
+func (c *Client) RequestStatement(ctx context.Context, acct BankAccount, opts StatementOptions) (*ofx.Document, error) {
+	body, err := c.marshalRequest(statementRequestDocument{
+		SignOn: c.signOnRequest(),
+		Bank: bankMessageSetRequest{
+			STMTTRNRQ: statementTrnRequest{
+				TrnUID: newTrnUID(),
+				STMTRQ: statementRequest{
+					BankAcctFrom: bankAccountFrom{
+						BankID:   acct.BankID,
+						AcctID:   acct.AccountID,
+						AcctType: acct.AccountType,
+					},
+					IncTran: incTranRequest{
+						DtStart: ofxDate(opts.Start),
+						DtEnd:   ofxDate(opts.End),
+						Include: yesNo(opts.IncludeTransactions),
+					},
+					IncludePending: yesNo(opts.IncludePending),
+					IncTranImg:     yesNo(opts.IncludeTranImage),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.post(ctx, body)
+}
+
+// InvAccount identifies the brokerage account to request a statement for.
+type InvAccount struct {
+	BrokerID  string
+	AccountID string
+}
+
+// InvStatementOptions configures a RequestInvStatement call.
+type InvStatementOptions struct {
+	// Start and End bound the transactions requested via INCTRAN. Either
+	// may be left zero to omit the corresponding DTSTART/DTEND.
+	Start, End time.Time
+	// IncludeTransactions sets INCTRAN's INCLUDE.
+	IncludeTransactions bool
+	// IncludePositions sets INCPOS's INCLUDE.
+	IncludePositions bool
+	// IncludeBalance sets INCBAL.
+	IncludeBalance bool
+	// Include401k sets INC401K.
+	Include401k bool
+	// IncludeOpenOrders sets INCOO.
+	IncludeOpenOrders bool
+}
+
+type invMessageSetRequest struct {
+	INVSTMTTRNRQ invStatementTrnRequest `xml:"INVSTMTTRNRQ"`
+}
+
+type invStatementTrnRequest struct {
+	TrnUID    string              `xml:"TRNUID"`
+	INVSTMTRQ invStatementRequest `xml:"INVSTMTRQ"`
+}
+
+type invStatementRequest struct {
+	InvAcctFrom invAccountFrom `xml:"INVACCTFROM"`
+	IncTran     incTranRequest `xml:"INCTRAN"`
+	IncOO       yesNo          `xml:"INCOO"`
+	IncPos      incPosRequest  `xml:"INCPOS"`
+	IncBal      yesNo          `xml:"INCBAL"`
+	Inc401K     yesNo          `xml:"INC401K"`
+}
+
+type invAccountFrom struct {
+	BrokerID string `xml:"BROKERID"`
+	AcctID   string `xml:"ACCTID"`
+}
+
+type incPosRequest struct {
+	Include yesNo `xml:"INCLUDE"`
+}
+
+type invStatementRequestDocument struct {
+	XMLName xml.Name                `xml:"OFX"`
+	SignOn  signOnMessageSetRequest `xml:"SIGNONMSGSRQV1"`
+	Inv     invMessageSetRequest    `xml:"INVSTMTMSGSRQV1"`
+}
+
+// RequestInvStatement requests a brokerage account statement for acct and
+// parses the server's response into an ofx.Document.
+func (c *Client) RequestInvStatement(ctx context.Context, acct InvAccount, opts InvStatementOptions) (*ofx.Document, error) {
+	body, err := c.marshalRequest(invStatementRequestDocument{
+		SignOn: c.signOnRequest(),
+		Inv: invMessageSetRequest{
+			INVSTMTTRNRQ: invStatementTrnRequest{
+				TrnUID: newTrnUID(),
+				INVSTMTRQ: invStatementRequest{
+					InvAcctFrom: invAccountFrom{
+						BrokerID: acct.BrokerID,
+						AcctID:   acct.AccountID,
+					},
+					IncTran: incTranRequest{
+						DtStart: ofxDate(opts.Start),
+						DtEnd:   ofxDate(opts.End),
+						Include: yesNo(opts.IncludeTransactions),
+					},
+					IncOO:   yesNo(opts.IncludeOpenOrders),
+					IncPos:  incPosRequest{Include: yesNo(opts.IncludePositions)},
+					IncBal:  yesNo(opts.IncludeBalance),
+					Inc401K: yesNo(opts.Include401k),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.post(ctx, body)
+}
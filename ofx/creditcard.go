@@ -0,0 +1,51 @@
+package ofx
+
+// CreditCardAccount identifies the credit-card account a statement belongs
+// to.
+type CreditCardAccount struct {
+	AccountID string `xml:"ACCTID"`
+}
+
+// CreditCardStatementResponseSet mirrors StatementResponseSet for credit
+// card accounts, which use CCACCTFROM in place of BANKACCTFROM.
+type CreditCardStatementResponseSet struct {
+	Currency         string            `xml:"CURDEF"`
+	Account          CreditCardAccount `xml:"CCACCTFROM"`
+	StartDate        Date              `xml:"BANKTRANLIST>DTSTART"`
+	EndDate          Date              `xml:"BANKTRANLIST>DTEND"`
+	Transactions     []Transaction     `xml:"BANKTRANLIST>STMTTRN"`
+	LedgerBalance    Balance           `xml:"LEDGERBAL"`
+	AvailableBalance Balance           `xml:"AVAILBAL"`
+}
+
+// CreditCardStatementTransactionResponseSet mirrors
+// StatementTransactionResponseSet for credit card accounts.
+type CreditCardStatementTransactionResponseSet struct {
+	ID       int                            `xml:"TRNUID"`
+	Code     int                            `xml:"STATUS>CODE"`
+	Severity string                         `xml:"STATUS>SEVERITY"`
+	RS       CreditCardStatementResponseSet `xml:"CCSTMTRS"`
+}
+
+// CreditCardMessageSet models CREDITCARDMSGSRSV1.
+type CreditCardMessageSet struct {
+	TRS CreditCardStatementTransactionResponseSet `xml:"CCSTMTTRNRS"`
+}
+
+func (m *CreditCardMessageSet) Name() string      { return "CREDITCARDMSGSRSV1" }
+func (m *CreditCardMessageSet) Type() MessageType { return CreditCardMessageType }
+
+func init() {
+	RegisterMessageSet("CREDITCARDMSGSRSV1", func() MessageSet { return &CreditCardMessageSet{} })
+}
+
+// CreditCard returns the first CREDITCARDMSGSRSV1 message set in the
+// document, or nil if none was present.
+func (d *Document) CreditCard() *CreditCardMessageSet {
+	if sets := d.MessageSets["CREDITCARDMSGSRSV1"]; len(sets) > 0 {
+		if ms, ok := sets[0].(*CreditCardMessageSet); ok {
+			return ms
+		}
+	}
+	return nil
+}
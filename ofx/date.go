@@ -0,0 +1,86 @@
+package ofx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// dateGrammar matches the OFX datetime grammar,
+// YYYYMMDDHHMMSS.XXX[gmt offset:tz name], per section 3.2.8.2 of the OFX
+// spec. Only the date is required; time, fractional seconds, and the GMT
+// offset are all optional.
+var dateGrammar = regexp.MustCompile(
+	`^(\d{4})(\d{2})(\d{2})(?:(\d{2})(\d{2})(\d{2}))?(?:\.(\d{3}))?(?:\[([+-]?[0-9.]+):(\w+)\])?$`)
+
+// Date is an OFX DTPOSTED/DTSTART/DTEND/DTASOF/DTSERVER/... value. It
+// exposes the parsed instant as a time.Time while preserving the original
+// wire string, since OFX data tags are otherwise free-form and re-marshaling
+// a reformatted date risks producing a string the originating institution
+// wouldn't recognize.
+type Date struct {
+	time.Time
+	raw string
+}
+
+// ParseDate parses s per the OFX datetime grammar. A GMT offset in brackets
+// is honored as the date's zone; otherwise GMT is assumed, per spec.
+func ParseDate(s string) (Date, error) {
+	m := dateGrammar.FindStringSubmatch(s)
+	if m == nil {
+		return Date{}, fmt.Errorf("ofx: malformed date %q", s)
+	}
+	year, _ := strconv.Atoi(m[1])
+	month, _ := strconv.Atoi(m[2])
+	day, _ := strconv.Atoi(m[3])
+	var hour, min, sec, nsec int
+	if m[4] != "" {
+		hour, _ = strconv.Atoi(m[4])
+		min, _ = strconv.Atoi(m[5])
+		sec, _ = strconv.Atoi(m[6])
+	}
+	if m[7] != "" {
+		millis, _ := strconv.Atoi(m[7])
+		nsec = millis * int(time.Millisecond)
+	}
+	loc := time.UTC
+	if m[8] != "" {
+		offsetHours, err := strconv.ParseFloat(m[8], 64)
+		if err != nil {
+			return Date{}, fmt.Errorf("ofx: malformed date %q: bad gmt offset: %w", s, err)
+		}
+		name := m[9]
+		if name == "" {
+			name = "OFX"
+		}
+		loc = time.FixedZone(name, int(offsetHours*float64(time.Hour/time.Second)))
+	}
+	return Date{Time: time.Date(year, time.Month(month), day, hour, min, sec, nsec, loc), raw: s}, nil
+}
+
+// String returns the original wire string, e.g. "20230105120000.000[-5:EST]".
+func (d Date) String() string {
+	return d.raw
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (d *Date) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := decoder.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, writing back the original wire
+// string rather than a reformatted one.
+func (d Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(d.raw, start)
+}
@@ -0,0 +1,85 @@
+package ofx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateDateOnly(t *testing.T) {
+	d, err := ParseDate("20230405")
+	if err != nil {
+		t.Fatalf("ParseDate: %v", err)
+	}
+	want := time.Date(2023, time.April, 5, 0, 0, 0, 0, time.UTC)
+	if !d.Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", d.Time, want)
+	}
+	if d.String() != "20230405" {
+		t.Errorf("String() = %q, want %q", d.String(), "20230405")
+	}
+}
+
+func TestParseDateWithTimeNoOffset(t *testing.T) {
+	d, err := ParseDate("20230405123045")
+	if err != nil {
+		t.Fatalf("ParseDate: %v", err)
+	}
+	want := time.Date(2023, time.April, 5, 12, 30, 45, 0, time.UTC)
+	if !d.Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", d.Time, want)
+	}
+}
+
+func TestParseDateWithFractionalSeconds(t *testing.T) {
+	d, err := ParseDate("20230405123045.500")
+	if err != nil {
+		t.Fatalf("ParseDate: %v", err)
+	}
+	if got, want := d.Time.Nanosecond(), 500*int(time.Millisecond); got != want {
+		t.Errorf("Nanosecond() = %d, want %d", got, want)
+	}
+}
+
+func TestParseDateWithGMTOffsetAndZoneName(t *testing.T) {
+	d, err := ParseDate("20230405123045[-5:EST]")
+	if err != nil {
+		t.Fatalf("ParseDate: %v", err)
+	}
+	name, offset := d.Time.Zone()
+	if name != "EST" {
+		t.Errorf("Zone name = %q, want EST", name)
+	}
+	if want := -5 * 60 * 60; offset != want {
+		t.Errorf("Zone offset = %d, want %d", offset, want)
+	}
+}
+
+func TestParseDateWithFractionalGMTOffset(t *testing.T) {
+	d, err := ParseDate("20230405123045[5.5:IST]")
+	if err != nil {
+		t.Fatalf("ParseDate: %v", err)
+	}
+	_, offset := d.Time.Zone()
+	if want := int(5.5 * 60 * 60); offset != want {
+		t.Errorf("Zone offset = %d, want %d", offset, want)
+	}
+}
+
+func TestParseDateRejectsMalformedInput(t *testing.T) {
+	for _, s := range []string{"", "2023", "not-a-date", "202304"} {
+		if _, err := ParseDate(s); err == nil {
+			t.Errorf("ParseDate(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+func TestParseDatePreservesWireStringOnRoundTrip(t *testing.T) {
+	const raw = "20230405123045.500[-5:EST]"
+	d, err := ParseDate(raw)
+	if err != nil {
+		t.Fatalf("ParseDate: %v", err)
+	}
+	if d.String() != raw {
+		t.Errorf("String() = %q, want the original wire string %q", d.String(), raw)
+	}
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+func TestBeancountWriterUsesMapperRuleOverTypeFallback(t *testing.T) {
+	mapped := testTransaction(t, ofx.DEBIT, "20230105000000", "-9.9900", "m1", "COFFEE SHOP")
+	unmapped := testTransaction(t, ofx.INTEREST, "20230106000000", "1.2300", "m2", "")
+	doc := testDocument(t, []ofx.Transaction{mapped, unmapped}, "75.0000")
+
+	mapper := &RuleMapper{Rules: []Rule{{Match: "coffee shop", Account: "Expenses:DiningOut"}}}
+	var buf bytes.Buffer
+	if err := NewBeancountWriter(&buf, "Assets:Checking", "USD", mapper).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "Expenses:DiningOut\n    Assets:Checking  -9.9900 USD") {
+		t.Errorf("beancount output missing mapper-rule posting with the signed amount on SourceAccount:\n%s", out)
+	}
+	if !strings.Contains(out, "Income:Interest\n    Assets:Checking  1.2300 USD") {
+		t.Errorf("beancount output missing type-fallback posting for unmatched INTEREST:\n%s", out)
+	}
+	if !strings.Contains(out, "balance Assets:Checking  75.0000 USD") {
+		t.Errorf("beancount output missing exact-string balance directive:\n%s", out)
+	}
+}
+
+func TestBeancountWriterPutsSignedAmountOnSourceAccountNotCategory(t *testing.T) {
+	// A -9.99 DEBIT (e.g. a coffee purchase) must leave the category
+	// account with a positive normal balance and SourceAccount with the raw
+	// signed amount, per Beancount convention - not the reverse.
+	txn := testTransaction(t, ofx.DEBIT, "20230105000000", "-9.99", "1", "COFFEE SHOP")
+	doc := testDocument(t, []ofx.Transaction{txn}, "0.00")
+
+	var buf bytes.Buffer
+	if err := NewBeancountWriter(&buf, "Assets:Checking", "USD", nil).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "    Expenses:Unknown\n    Assets:Checking  -9.99 USD\n") {
+		t.Errorf("expected the signed amount on the Assets:Checking posting, got:\n%s", out)
+	}
+	if strings.Contains(out, "Expenses:Unknown  -9.99 USD") {
+		t.Errorf("category account must not carry the raw signed amount:\n%s", out)
+	}
+}
+
+func TestBeancountWriterOmitsBalanceWithoutBankOrCreditCard(t *testing.T) {
+	doc := &ofx.Document{MessageSets: map[string][]ofx.MessageSet{}}
+	var buf bytes.Buffer
+	if err := NewBeancountWriter(&buf, "Assets:Checking", "USD", nil).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output without a bank or credit card statement, got %q", buf.String())
+	}
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+// DefaultCSVColumns is the column set CSVWriter uses when Columns is nil.
+var DefaultCSVColumns = []string{"Date", "Type", "Amount", "ID", "Name", "Payee", "Memo"}
+
+// CSVWriter writes a Document's transactions (see ofx.Document.Transactions)
+// as CSV, one row per transaction.
+type CSVWriter struct {
+	w       *csv.Writer
+	Columns []string // defaults to DefaultCSVColumns.
+}
+
+// NewCSVWriter returns a CSVWriter that writes to w.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), Columns: DefaultCSVColumns}
+}
+
+// Write implements Writer.
+func (cw *CSVWriter) Write(doc *ofx.Document) error {
+	if err := cw.w.Write(cw.Columns); err != nil {
+		return err
+	}
+	for _, txn := range doc.Transactions() {
+		row := make([]string, len(cw.Columns))
+		for i, col := range cw.Columns {
+			row[i] = csvField(txn, col)
+		}
+		if err := cw.w.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func csvField(txn ofx.Transaction, column string) string {
+	switch column {
+	case "Date":
+		return txn.Posted.Format("2006-01-02")
+	case "Type":
+		return string(txn.Type)
+	case "Amount":
+		return txn.Amount.String()
+	case "ID":
+		return txn.ID
+	case "Name":
+		return txn.Name
+	case "Payee":
+		return txn.Payee
+	case "Memo":
+		return txn.Memo
+	default:
+		return ""
+	}
+}
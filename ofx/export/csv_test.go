@@ -0,0 +1,46 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+func TestCSVWriterWritesDefaultColumns(t *testing.T) {
+	txn := testTransaction(t, ofx.DEBIT, "20230105000000", "-12.3400", "1", "TRADER JOE'S")
+	doc := testDocument(t, []ofx.Transaction{txn}, "100.00")
+
+	var buf bytes.Buffer
+	if err := NewCSVWriter(&buf).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2 (header + 1 row): %q", len(lines), buf.String())
+	}
+	if want := "Date,Type,Amount,ID,Name,Payee,Memo"; lines[0] != want {
+		t.Errorf("header = %q, want %q", lines[0], want)
+	}
+	if want := "2023-01-05,DEBIT,-12.3400,1,,TRADER JOE'S,"; lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+}
+
+func TestCSVWriterHonorsCustomColumns(t *testing.T) {
+	txn := testTransaction(t, ofx.FEE, "20230105000000", "-2.50", "1", "")
+	doc := testDocument(t, []ofx.Transaction{txn}, "0.00")
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+	w.Columns = []string{"Amount", "Type"}
+	if err := w.Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := "Amount,Type\n-2.50,FEE\n"
+	if buf.String() != want {
+		t.Errorf("csv output = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,99 @@
+// Package export writes a parsed ofx.Document out in other formats: JSON,
+// CSV, and double-entry plain-text accounting (ledger-cli/hledger and
+// Beancount).
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+// Writer writes a parsed Document to an io.Writer in some export format.
+type Writer interface {
+	Write(doc *ofx.Document) error
+}
+
+// AccountMapper maps a transaction to the ledger account it should post
+// against, e.g. "Expenses:Groceries" for a grocery store payee. It is
+// consulted by LedgerWriter and BeancountWriter.
+type AccountMapper interface {
+	Account(txn ofx.Transaction) string
+}
+
+// Rule matches a transaction whose Payee or Name contains Match
+// (case-insensitively) to Account.
+type Rule struct {
+	Match   string `json:"match"`
+	Account string `json:"account"`
+}
+
+// RuleMapper is an AccountMapper backed by an ordered list of substring
+// Rules, falling back to Default when none match, and to
+// DefaultAccountForType when Default is also empty.
+type RuleMapper struct {
+	Rules   []Rule `json:"rules"`
+	Default string `json:"default"`
+}
+
+// Account implements AccountMapper.
+func (m *RuleMapper) Account(txn ofx.Transaction) string {
+	name := txn.Payee
+	if name == "" {
+		name = txn.Name
+	}
+	name = strings.ToLower(name)
+	for _, rule := range m.Rules {
+		if strings.Contains(name, strings.ToLower(rule.Match)) {
+			return rule.Account
+		}
+	}
+	if m.Default != "" {
+		return m.Default
+	}
+	return DefaultAccountForType(txn.Type)
+}
+
+// typeAccounts maps a TransactionType to the conventional account a
+// double-entry posting lands in when no payee rule overrides it, e.g. a FEE
+// posts against "Expenses:Fees" rather than the generic expense bucket a
+// POS purchase with no matching rule would.
+var typeAccounts = map[ofx.TransactionType]string{
+	ofx.CREDIT:        "Income:Unknown",
+	ofx.DEPOSIT:       "Income:Unknown",
+	ofx.DIRECTDEPOSIT: "Income:Unknown",
+	ofx.INTEREST:      "Income:Interest",
+	ofx.DIVIDENT:      "Income:Dividends",
+	ofx.FEE:           "Expenses:Fees",
+	ofx.SERVICECHARGE: "Expenses:Fees",
+}
+
+// DefaultAccountForType returns the conventional account a transaction of
+// type t posts against absent any more specific AccountMapper rule.
+// Transaction types with no more specific convention (DEBIT, CHECK,
+// PAYMENT, CASH, POS, ATM, TRANSFER, ...) fall back to "Expenses:Unknown".
+func DefaultAccountForType(t ofx.TransactionType) string {
+	if account, ok := typeAccounts[t]; ok {
+		return account
+	}
+	return "Expenses:Unknown"
+}
+
+// LoadRuleMapper reads payee/name-to-account Rules from a JSON config file
+// shaped like:
+//
+//	{"rules": [{"match": "trader joe", "account": "Expenses:Groceries"}],
+//	 "default": "Expenses:Unknown"}
+func LoadRuleMapper(filename string) (*RuleMapper, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	mapper := &RuleMapper{}
+	if err := json.Unmarshal(data, mapper); err != nil {
+		return nil, err
+	}
+	return mapper, nil
+}
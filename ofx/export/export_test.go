@@ -0,0 +1,92 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+// testDocument builds a minimal *ofx.Document with a single bank message
+// set carrying txns and a ledger balance of balAmt, for writer tests.
+func testDocument(t *testing.T, txns []ofx.Transaction, balAmt string) *ofx.Document {
+	t.Helper()
+	amount, err := ofx.ParseAmount(balAmt)
+	if err != nil {
+		t.Fatalf("ParseAmount(%q): %v", balAmt, err)
+	}
+	date, err := ofx.ParseDate("20230131000000")
+	if err != nil {
+		t.Fatalf("ParseDate: %v", err)
+	}
+	bank := &ofx.BankMessageSet{
+		TRS: ofx.StatementTransactionResponseSet{
+			RS: ofx.StatementResponseSet{
+				Transactions:  txns,
+				LedgerBalance: ofx.Balance{Amount: amount, Date: date},
+			},
+		},
+	}
+	return &ofx.Document{MessageSets: map[string][]ofx.MessageSet{"BANKMSGSRSV1": {bank}}}
+}
+
+func testTransaction(t *testing.T, typ ofx.TransactionType, posted, amount, id, payee string) ofx.Transaction {
+	t.Helper()
+	amt, err := ofx.ParseAmount(amount)
+	if err != nil {
+		t.Fatalf("ParseAmount(%q): %v", amount, err)
+	}
+	date, err := ofx.ParseDate(posted)
+	if err != nil {
+		t.Fatalf("ParseDate(%q): %v", posted, err)
+	}
+	return ofx.Transaction{Type: typ, Posted: date, Amount: amt, ID: id, Payee: payee}
+}
+
+func TestRuleMapperAccountMatchesRuleBeforeDefault(t *testing.T) {
+	mapper := &RuleMapper{
+		Rules:   []Rule{{Match: "trader joe", Account: "Expenses:Groceries"}},
+		Default: "Expenses:Catchall",
+	}
+	txn := testTransaction(t, ofx.DEBIT, "20230105000000", "-12.34", "1", "TRADER JOE'S #123")
+	if got, want := mapper.Account(txn), "Expenses:Groceries"; got != want {
+		t.Errorf("Account() = %q, want %q", got, want)
+	}
+}
+
+func TestRuleMapperAccountFallsBackToDefault(t *testing.T) {
+	mapper := &RuleMapper{Default: "Expenses:Catchall"}
+	txn := testTransaction(t, ofx.DEBIT, "20230105000000", "-5.00", "1", "UNKNOWN MERCHANT")
+	if got, want := mapper.Account(txn), "Expenses:Catchall"; got != want {
+		t.Errorf("Account() = %q, want %q", got, want)
+	}
+}
+
+func TestRuleMapperAccountFallsBackToTypeWhenNoDefault(t *testing.T) {
+	mapper := &RuleMapper{}
+	txn := testTransaction(t, ofx.FEE, "20230105000000", "-2.50", "1", "MONTHLY FEE")
+	if got, want := mapper.Account(txn), "Expenses:Fees"; got != want {
+		t.Errorf("Account() = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultAccountForType(t *testing.T) {
+	tests := []struct {
+		typ  ofx.TransactionType
+		want string
+	}{
+		{ofx.FEE, "Expenses:Fees"},
+		{ofx.SERVICECHARGE, "Expenses:Fees"},
+		{ofx.INTEREST, "Income:Interest"},
+		{ofx.DIVIDENT, "Income:Dividends"},
+		{ofx.CREDIT, "Income:Unknown"},
+		{ofx.DEPOSIT, "Income:Unknown"},
+		{ofx.DIRECTDEPOSIT, "Income:Unknown"},
+		{ofx.DEBIT, "Expenses:Unknown"},
+		{ofx.POS, "Expenses:Unknown"},
+	}
+	for _, tt := range tests {
+		if got := DefaultAccountForType(tt.typ); got != tt.want {
+			t.Errorf("DefaultAccountForType(%v) = %q, want %q", tt.typ, got, tt.want)
+		}
+	}
+}
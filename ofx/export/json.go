@@ -0,0 +1,28 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+// JSONWriter writes a Document as a single JSON object.
+type JSONWriter struct {
+	w      io.Writer
+	Indent string // set to e.g. "  " to pretty-print; empty writes compact JSON.
+}
+
+// NewJSONWriter returns a JSONWriter that writes to w.
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{w: w}
+}
+
+// Write implements Writer.
+func (jw *JSONWriter) Write(doc *ofx.Document) error {
+	enc := json.NewEncoder(jw.w)
+	if jw.Indent != "" {
+		enc.SetIndent("", jw.Indent)
+	}
+	return enc.Encode(doc)
+}
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+// LedgerWriter writes a Document as ledger-cli/hledger plain-text entries:
+// one double-entry posting per transaction against SourceAccount, plus a
+// balance assertion for the statement's ending ledger balance, if any.
+type LedgerWriter struct {
+	w io.Writer
+
+	// SourceAccount is the account the statement itself represents, e.g.
+	// "Assets:Checking". It appears on the second posting line of every
+	// transaction and in the trailing balance assertion.
+	SourceAccount string
+	// Mapper chooses the account each transaction posts against on its
+	// first posting line. If nil, or if it returns "", DefaultAccountForType
+	// is used instead.
+	Mapper AccountMapper
+}
+
+// NewLedgerWriter returns a LedgerWriter that writes to w, posting against
+// sourceAccount and using mapper to choose the offsetting account for each
+// transaction.
+func NewLedgerWriter(w io.Writer, sourceAccount string, mapper AccountMapper) *LedgerWriter {
+	return &LedgerWriter{w: w, SourceAccount: sourceAccount, Mapper: mapper}
+}
+
+// Write implements Writer.
+func (lw *LedgerWriter) Write(doc *ofx.Document) error {
+	for _, txn := range doc.Transactions() {
+		if err := lw.writeTransaction(txn); err != nil {
+			return err
+		}
+	}
+	return lw.writeBalance(doc)
+}
+
+func (lw *LedgerWriter) writeTransaction(txn ofx.Transaction) error {
+	payee := txn.Payee
+	if payee == "" {
+		payee = txn.Name
+	}
+	account := ""
+	if lw.Mapper != nil {
+		account = lw.Mapper.Account(txn)
+	}
+	if account == "" {
+		account = DefaultAccountForType(txn.Type)
+	}
+	// txn.Amount is signed relative to SourceAccount (negative when money
+	// leaves it), so it belongs on SourceAccount's posting; account
+	// auto-balances to the negation, giving it the normal positive balance
+	// ledger-cli/hledger convention expects for an expense.
+	_, err := fmt.Fprintf(lw.w, "%s %s\n    ; fitid: %s\n    %s\n    %s  %s\n\n",
+		txn.Posted.Format("2006-01-02"), payee, txn.ID, account, lw.SourceAccount, txn.Amount.String())
+	return err
+}
+
+// writeBalance emits a balance assertion for the statement's ending ledger
+// balance, so `ledger` or `hledger` flags the entry if our postings drift
+// from what the institution reported.
+func (lw *LedgerWriter) writeBalance(doc *ofx.Document) error {
+	var bal ofx.Balance
+	switch {
+	case doc.Bank() != nil:
+		bal = doc.Bank().TRS.RS.LedgerBalance
+	case doc.CreditCard() != nil:
+		bal = doc.CreditCard().TRS.RS.LedgerBalance
+	default:
+		return nil
+	}
+	_, err := fmt.Fprintf(lw.w, "%s balance assertion\n    %s  = %s\n\n",
+		bal.Date.Format("2006-01-02"), lw.SourceAccount, bal.Amount.String())
+	return err
+}
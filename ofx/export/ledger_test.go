@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rockstardevs/parser/ofx"
+)
+
+func TestLedgerWriterUsesMapperThenTypeFallback(t *testing.T) {
+	mapped := testTransaction(t, ofx.DEBIT, "20230105000000", "-12.3400", "m1", "TRADER JOE'S")
+	unmapped := testTransaction(t, ofx.FEE, "20230106000000", "-2.50", "m2", "MONTHLY FEE")
+	doc := testDocument(t, []ofx.Transaction{mapped, unmapped}, "50.0000")
+
+	mapper := &RuleMapper{Rules: []Rule{{Match: "trader joe", Account: "Expenses:Groceries"}}}
+	var buf bytes.Buffer
+	if err := NewLedgerWriter(&buf, "Assets:Checking", mapper).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "Expenses:Groceries\n    Assets:Checking  -12.3400") {
+		t.Errorf("ledger output missing mapped posting with the signed amount on SourceAccount:\n%s", out)
+	}
+	if !strings.Contains(out, "Expenses:Fees\n    Assets:Checking  -2.50") {
+		t.Errorf("ledger output missing type-fallback posting for unmatched FEE:\n%s", out)
+	}
+	if !strings.Contains(out, "Assets:Checking  = 50.0000") {
+		t.Errorf("ledger output missing exact-string balance assertion:\n%s", out)
+	}
+}
+
+func TestLedgerWriterPutsSignedAmountOnSourceAccountNotCategory(t *testing.T) {
+	// A -12.34 DEBIT (e.g. a coffee purchase) must leave the category
+	// account with a positive normal balance and SourceAccount with the raw
+	// signed amount, per ledger-cli/hledger convention - not the reverse.
+	txn := testTransaction(t, ofx.DEBIT, "20230105000000", "-12.34", "1", "COFFEE SHOP")
+	doc := testDocument(t, []ofx.Transaction{txn}, "0.00")
+
+	var buf bytes.Buffer
+	if err := NewLedgerWriter(&buf, "Assets:Checking", nil).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "    Expenses:Unknown\n    Assets:Checking  -12.34\n") {
+		t.Errorf("expected the signed amount on the Assets:Checking posting, got:\n%s", out)
+	}
+	if strings.Contains(out, "Expenses:Unknown  -12.34") {
+		t.Errorf("category account must not carry the raw signed amount:\n%s", out)
+	}
+}
+
+func TestLedgerWriterOmitsBalanceWithoutBankOrCreditCard(t *testing.T) {
+	doc := &ofx.Document{MessageSets: map[string][]ofx.MessageSet{}}
+	var buf bytes.Buffer
+	if err := NewLedgerWriter(&buf, "Assets:Checking", nil).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output without a bank or credit card statement, got %q", buf.String())
+	}
+}
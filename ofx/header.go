@@ -0,0 +1,196 @@
+package ofx
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Header holds the OFX/QFX SGML preamble fields that precede the <OFX>
+// aggregate, e.g.:
+//
+//	OFXHEADER:100
+//	DATA:OFXSGML
+//	VERSION:102
+//	SECURITY:NONE
+//	ENCODING:USASCII
+//	CHARSET:1252
+//	COMPRESSION:NONE
+//	OLDFILEUID:NONE
+//	NEWFILEUID:NONE
+type Header struct {
+	OFXHeader   string
+	Data        string
+	Version     string
+	Security    string
+	Encoding    string
+	Charset     string
+	Compression string
+	OldFileUID  string
+	NewFileUID  string
+}
+
+// IsXML reports whether the OFX body is true XML (OFX 2.x, VERSION >= 200)
+// rather than the SGML-derived syntax used by OFX/QFX 1.x, where it is well
+// formed and can be unmarshaled directly.
+func (h *Header) IsXML() bool {
+	version, err := strconv.Atoi(h.Version)
+	return err == nil && version >= 200
+}
+
+// charsetEncoding returns the encoding.Encoding declared by the header's
+// ENCODING/CHARSET fields, or nil if the body is already UTF-8/ASCII and
+// needs no transcoding. It returns a descriptive error for any
+// ENCODING/CHARSET combination it does not recognize rather than passing
+// the bytes through untranslated.
+func (h *Header) charsetEncoding() (encoding.Encoding, error) {
+	switch strings.ToUpper(strings.TrimSpace(h.Encoding)) {
+	case "", "USASCII":
+		switch strings.ToUpper(strings.TrimSpace(h.Charset)) {
+		case "", "NONE":
+			return nil, nil
+		case "1252":
+			return charmap.Windows1252, nil
+		case "8859-1":
+			return charmap.ISO8859_1, nil
+		default:
+			return nil, fmt.Errorf("ofx: unsupported CHARSET %q for ENCODING USASCII", h.Charset)
+		}
+	case "UTF-8":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("ofx: unsupported ENCODING %q", h.Encoding)
+	}
+}
+
+// setHeaderField assigns value to the Header field named by key (matched
+// case-insensitively, as both the SGML header lines and the OFX 2.x
+// processing-instruction attributes use uppercase names), ignoring any
+// FI-specific extension field this package does not model.
+func setHeaderField(header *Header, key, value string) {
+	switch strings.ToUpper(strings.TrimSpace(key)) {
+	case "OFXHEADER":
+		header.OFXHeader = value
+	case "DATA":
+		header.Data = value
+	case "VERSION":
+		header.Version = value
+	case "SECURITY":
+		header.Security = value
+	case "ENCODING":
+		header.Encoding = value
+	case "CHARSET":
+		header.Charset = value
+	case "COMPRESSION":
+		header.Compression = value
+	case "OLDFILEUID":
+		header.OldFileUID = value
+	case "NEWFILEUID":
+		header.NewFileUID = value
+	}
+}
+
+// parseHeader parses the preamble that precedes the <OFX> aggregate and
+// returns the parsed Header along with the byte offset in data at which the
+// OFX body begins. OFX/QFX 1.x uses colon-delimited SGML header lines; OFX
+// 2.x instead opens with an XML prolog, e.g.:
+//
+//	<?xml version="1.0" encoding="UTF-8"?>
+//	<?OFX OFXHEADER="200" VERSION="200" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+//
+// In strict mode a malformed SGML header line fails the parse; in lenient
+// mode it is skipped.
+func parseHeader(data []byte, strict bool) (*Header, int, error) {
+	if bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("<?")) {
+		return parseXMLHeader(data, strict)
+	}
+	return parseSGMLHeader(data, strict)
+}
+
+// parseSGMLHeader parses the OFX/QFX 1.x colon-delimited header lines that
+// precede the <OFX> aggregate (everything up to the first '<').
+func parseSGMLHeader(data []byte, strict bool) (*Header, int, error) {
+	bodyIndex := bytes.IndexByte(data, '<')
+	if bodyIndex == -1 {
+		return nil, 0, fmt.Errorf("ofx: invalid file, no OFX body found")
+	}
+
+	header := &Header{}
+	scanner := bufio.NewScanner(bytes.NewReader(data[:bodyIndex]))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			if strict {
+				return nil, 0, fmt.Errorf("ofx: malformed header line %q", line)
+			}
+			continue
+		}
+		setHeaderField(header, key, strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	return header, bodyIndex, nil
+}
+
+// xmlProcessingInstruction matches a single <?target attrs?> processing
+// instruction.
+var xmlProcessingInstruction = regexp.MustCompile(`(?s)^<\?(\w+)(.*?)\?>`)
+
+// xmlAttribute matches a single name="value" attribute within a processing
+// instruction's attribute list.
+var xmlAttribute = regexp.MustCompile(`(\w+)\s*=\s*"([^"]*)"`)
+
+// parseXMLHeader parses the OFX 2.x preamble: a leading <?xml ...?> prolog
+// followed by a <?OFX ...?> processing instruction carrying the same fields
+// the SGML header expresses as colon-delimited lines (VERSION, SECURITY,
+// ...), e.g. <?OFX OFXHEADER="200" VERSION="200" SECURITY="NONE" ...?>. Any
+// "encoding" attribute on the XML prolog itself is also honored, so a
+// document declaring e.g. ISO-8859-1 still has its CHARSET handling apply.
+func parseXMLHeader(data []byte, strict bool) (*Header, int, error) {
+	header := &Header{}
+	pos := 0
+	for {
+		rest := bytes.TrimLeft(data[pos:], " \t\r\n")
+		pos += len(data[pos:]) - len(rest)
+
+		m := xmlProcessingInstruction.FindSubmatch(data[pos:])
+		if m == nil {
+			break
+		}
+		target := string(m[1])
+		for _, am := range xmlAttribute.FindAllSubmatch(m[2], -1) {
+			key := string(am[1])
+			// Only the <?OFX ...?> PI's attributes map onto Header fields
+			// (OFXHEADER, VERSION, SECURITY, ...); the leading <?xml ...?>
+			// prolog's own "version" attribute is the XML declaration
+			// version (always "1.0"), not the OFX VERSION. Its "encoding"
+			// attribute is the one exception worth keeping, since it drives
+			// the same CHARSET handling the SGML header's ENCODING does.
+			if strings.EqualFold(target, "OFX") {
+				setHeaderField(header, key, string(am[2]))
+			} else if strings.EqualFold(target, "xml") && strings.EqualFold(key, "encoding") {
+				setHeaderField(header, key, string(am[2]))
+			}
+		}
+		pos += len(m[0])
+	}
+	rest := bytes.TrimLeft(data[pos:], " \t\r\n")
+	bodyIndex := pos + (len(data[pos:]) - len(rest))
+	if bodyIndex >= len(data) || data[bodyIndex] != '<' {
+		if strict {
+			return nil, 0, fmt.Errorf("ofx: invalid file, no OFX body found after XML prolog")
+		}
+	}
+	return header, bodyIndex, nil
+}
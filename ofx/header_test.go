@@ -0,0 +1,201 @@
+package ofx
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestIsXML(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"102", false},
+		{"151", false},
+		{"200", true},
+		{"220", true},
+		{"", false},
+		{"not-a-number", false},
+	}
+	for _, tt := range tests {
+		h := &Header{Version: tt.version}
+		if got := h.IsXML(); got != tt.want {
+			t.Errorf("Header{Version: %q}.IsXML() = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseHeaderDispatchesSGMLVsXML(t *testing.T) {
+	sgml := []byte("OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n<OFX></OFX>")
+	h, bodyIndex, err := parseHeader(sgml, true)
+	if err != nil {
+		t.Fatalf("parseHeader(sgml): %v", err)
+	}
+	if h.Version != "102" {
+		t.Errorf("Version = %q, want 102", h.Version)
+	}
+	if got, want := string(sgml[bodyIndex:]), "<OFX></OFX>"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+
+	xmlDoc := []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<?OFX OFXHEADER=\"200\" VERSION=\"200\" SECURITY=\"NONE\" OLDFILEUID=\"NONE\" NEWFILEUID=\"NONE\"?>\n<OFX></OFX>")
+	h, bodyIndex, err = parseHeader(xmlDoc, true)
+	if err != nil {
+		t.Fatalf("parseHeader(xml): %v", err)
+	}
+	if h.Version != "200" {
+		t.Errorf("Version = %q, want 200", h.Version)
+	}
+	if !h.IsXML() {
+		t.Error("IsXML() = false for a VERSION 200 document, want true")
+	}
+	if got, want := string(xmlDoc[bodyIndex:]), "<OFX></OFX>"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestParseSGMLHeaderParsesAllFields(t *testing.T) {
+	data := []byte("OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\nSECURITY:NONE\nENCODING:USASCII\nCHARSET:1252\nCOMPRESSION:NONE\nOLDFILEUID:NONE\nNEWFILEUID:NONE\n\n<OFX></OFX>")
+	h, bodyIndex, err := parseSGMLHeader(data, true)
+	if err != nil {
+		t.Fatalf("parseSGMLHeader: %v", err)
+	}
+	want := &Header{
+		OFXHeader: "100", Data: "OFXSGML", Version: "102", Security: "NONE",
+		Encoding: "USASCII", Charset: "1252", Compression: "NONE",
+		OldFileUID: "NONE", NewFileUID: "NONE",
+	}
+	if *h != *want {
+		t.Errorf("parseSGMLHeader = %+v, want %+v", h, want)
+	}
+	if got, want := string(data[bodyIndex:]), "<OFX></OFX>"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestParseSGMLHeaderNoBodyIsAnError(t *testing.T) {
+	if _, _, err := parseSGMLHeader([]byte("VERSION:102\n"), false); err == nil {
+		t.Error("parseSGMLHeader with no '<' in the input succeeded, want an error")
+	}
+}
+
+func TestParseSGMLHeaderMalformedLine(t *testing.T) {
+	data := []byte("OFXHEADER:100\nnot a valid header line\nVERSION:102\n\n<OFX></OFX>")
+
+	if _, _, err := parseSGMLHeader(data, true); err == nil {
+		t.Error("parseSGMLHeader(strict=true) with a malformed line succeeded, want an error")
+	}
+
+	h, _, err := parseSGMLHeader(data, false)
+	if err != nil {
+		t.Fatalf("parseSGMLHeader(strict=false): %v", err)
+	}
+	if h.Version != "102" {
+		t.Errorf("Version = %q, want 102 (malformed line should be skipped, not abort the parse)", h.Version)
+	}
+}
+
+func TestParseXMLHeaderIgnoresXMLDeclarationVersion(t *testing.T) {
+	data := []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n<?OFX OFXHEADER=\"200\" VERSION=\"211\" SECURITY=\"NONE\" OLDFILEUID=\"NONE\" NEWFILEUID=\"NONE\"?>\n<OFX></OFX>")
+	h, bodyIndex, err := parseXMLHeader(data, true)
+	if err != nil {
+		t.Fatalf("parseXMLHeader: %v", err)
+	}
+	if h.Version != "211" {
+		t.Errorf("Version = %q, want 211 (from the <?OFX?> PI, not the XML declaration's \"1.0\")", h.Version)
+	}
+	if h.Encoding != "ISO-8859-1" {
+		t.Errorf("Encoding = %q, want ISO-8859-1 (carried over from the <?xml?> prolog)", h.Encoding)
+	}
+	if got, want := string(data[bodyIndex:]), "<OFX></OFX>"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestParseXMLHeaderStrictFailsWithoutBody(t *testing.T) {
+	data := []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<?OFX OFXHEADER=\"200\" VERSION=\"200\"?>\n")
+	if _, _, err := parseXMLHeader(data, true); err == nil {
+		t.Error("parseXMLHeader(strict=true) with no OFX body after the prolog succeeded, want an error")
+	}
+	if _, _, err := parseXMLHeader(data, false); err != nil {
+		t.Errorf("parseXMLHeader(strict=false) with no OFX body after the prolog failed: %v", err)
+	}
+}
+
+func TestCharsetEncoding(t *testing.T) {
+	tests := []struct {
+		name       string
+		encoding   string
+		charset    string
+		wantNil    bool
+		wantErr    bool
+		wantResult encoding.Encoding
+	}{
+		{name: "ascii no charset", encoding: "USASCII", charset: "NONE", wantNil: true},
+		{name: "empty encoding and charset", encoding: "", charset: "", wantNil: true},
+		{name: "utf-8", encoding: "UTF-8", charset: "", wantNil: true},
+		{name: "windows-1252", encoding: "USASCII", charset: "1252", wantResult: charmap.Windows1252},
+		{name: "iso-8859-1", encoding: "USASCII", charset: "8859-1", wantResult: charmap.ISO8859_1},
+		{name: "unsupported charset", encoding: "USASCII", charset: "9999", wantErr: true},
+		{name: "unsupported encoding", encoding: "SHIFT-JIS", charset: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Header{Encoding: tt.encoding, Charset: tt.charset}
+			got, err := h.charsetEncoding()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("charsetEncoding() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("charsetEncoding(): %v", err)
+			}
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("charsetEncoding() = %v, want nil", got)
+				}
+				return
+			}
+			if got != tt.wantResult {
+				t.Errorf("charsetEncoding() = %v, want %v", got, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestParseHeaderCP1252RoundTrip(t *testing.T) {
+	// Regression for the CP1252 transcoding path: a 1.x header declaring
+	// CHARSET:1252 should resolve to charmap.Windows1252, which the Parser
+	// then uses to transcode the body.
+	data := []byte("OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\nENCODING:USASCII\nCHARSET:1252\n\n<OFX></OFX>")
+	h, _, err := parseHeader(data, true)
+	if err != nil {
+		t.Fatalf("parseHeader: %v", err)
+	}
+	enc, err := h.charsetEncoding()
+	if err != nil {
+		t.Fatalf("charsetEncoding: %v", err)
+	}
+	if enc != charmap.Windows1252 {
+		t.Errorf("charsetEncoding() = %v, want charmap.Windows1252", enc)
+	}
+}
+
+func TestParseHeaderEmptyInputSkipsAllWhitespace(t *testing.T) {
+	data := []byte("   \n\t\n<OFX></OFX>")
+	h, bodyIndex, err := parseHeader(data, false)
+	if err != nil {
+		t.Fatalf("parseHeader: %v", err)
+	}
+	if h.Version != "" {
+		t.Errorf("Version = %q, want empty for a header-less document", h.Version)
+	}
+	if got := strings.TrimSpace(string(data[bodyIndex:])); got != "<OFX></OFX>" {
+		t.Errorf("body = %q, want <OFX></OFX>", got)
+	}
+}
@@ -0,0 +1,101 @@
+package ofx
+
+// InvestmentAccount identifies the brokerage account an investment
+// statement belongs to.
+type InvestmentAccount struct {
+	BrokerID  string `xml:"BROKERID"`
+	AccountID string `xml:"ACCTID"`
+}
+
+// InvestmentBankTransaction is a cash transaction posted against an
+// investment account's sweep/settlement fund, i.e. INVBANKTRAN, which
+// wraps an ordinary banking Transaction.
+type InvestmentBankTransaction struct {
+	SubAccount  string      `xml:"SUBACCTFUND"`
+	Transaction Transaction `xml:"STMTTRN"`
+}
+
+// InvestmentTransactionList is INVTRANLIST. The OFX spec also defines
+// security buy/sell/income transaction types (BUYSTOCK, SELLSTOCK, INCOME,
+// ...) as siblings of INVBANKTRAN here; this does not implement the
+// complete rfc spec yet and only models the cash-transaction case.
+type InvestmentTransactionList struct {
+	StartDate    Date                        `xml:"DTSTART"`
+	EndDate      Date                        `xml:"DTEND"`
+	Transactions []InvestmentBankTransaction `xml:"INVBANKTRAN"`
+}
+
+// InvestmentPosition is a single holding reported in INVPOSLIST, e.g.
+// POSSTOCK or POSMF.
+type InvestmentPosition struct {
+	SecurityID   string `xml:"INVPOS>SECID>UNIQUEID"`
+	SecurityType string `xml:"INVPOS>SECID>UNIQUEIDTYPE"`
+	Units        Amount `xml:"INVPOS>UNITS"`
+	UnitPrice    Amount `xml:"INVPOS>UNITPRICE"`
+	MarketValue  Amount `xml:"INVPOS>MKTVAL"`
+	DatePriced   Date   `xml:"INVPOS>DTPRICEASOF"`
+}
+
+// InvestmentPositionList is INVPOSLIST.
+type InvestmentPositionList struct {
+	Stocks      []InvestmentPosition `xml:"POSSTOCK"`
+	MutualFunds []InvestmentPosition `xml:"POSMF"`
+	Options     []InvestmentPosition `xml:"POSOPT"`
+	Other       []InvestmentPosition `xml:"POSOTHER"`
+	Debt        []InvestmentPosition `xml:"POSDEBT"`
+}
+
+// InvestmentBalance is INVBAL.
+type InvestmentBalance struct {
+	AvailableCash Amount `xml:"AVAILCASH"`
+	MarginBalance Amount `xml:"MARGINBALANCE"`
+	ShortBalance  Amount `xml:"SHORTBALANCE"`
+}
+
+// Investment401k is INV401K, the employer retirement-plan summary OFX
+// allows alongside a regular investment statement.
+type Investment401k struct {
+	EmployerName string `xml:"401KSOURCE,omitempty"`
+	CashBalance  Amount `xml:"CASHBALANCE,omitempty"`
+}
+
+// InvestmentStatementResponseSet is INVSTMTRS.
+type InvestmentStatementResponseSet struct {
+	Currency     string                    `xml:"CURDEF"`
+	Account      InvestmentAccount         `xml:"INVACCTFROM"`
+	Transactions InvestmentTransactionList `xml:"INVTRANLIST"`
+	Positions    InvestmentPositionList    `xml:"INVPOSLIST"`
+	Balance      InvestmentBalance         `xml:"INVBAL"`
+	FourOOneK    Investment401k            `xml:"INV401K,omitempty"`
+}
+
+// InvestmentStatementTransactionResponseSet is INVSTMTTRNRS.
+type InvestmentStatementTransactionResponseSet struct {
+	ID       int                            `xml:"TRNUID"`
+	Code     int                            `xml:"STATUS>CODE"`
+	Severity string                         `xml:"STATUS>SEVERITY"`
+	RS       InvestmentStatementResponseSet `xml:"INVSTMTRS"`
+}
+
+// InvestmentMessageSet models INVSTMTMSGSRSV1.
+type InvestmentMessageSet struct {
+	TRS InvestmentStatementTransactionResponseSet `xml:"INVSTMTTRNRS"`
+}
+
+func (m *InvestmentMessageSet) Name() string      { return "INVSTMTMSGSRSV1" }
+func (m *InvestmentMessageSet) Type() MessageType { return InvestmentMessageType }
+
+func init() {
+	RegisterMessageSet("INVSTMTMSGSRSV1", func() MessageSet { return &InvestmentMessageSet{} })
+}
+
+// Investment returns the first INVSTMTMSGSRSV1 message set in the document,
+// or nil if none was present.
+func (d *Document) Investment() *InvestmentMessageSet {
+	if sets := d.MessageSets["INVSTMTMSGSRSV1"]; len(sets) > 0 {
+		if ms, ok := sets[0].(*InvestmentMessageSet); ok {
+			return ms
+		}
+	}
+	return nil
+}
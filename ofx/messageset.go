@@ -0,0 +1,158 @@
+package ofx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// MessageType identifies the category of OFX message set an aggregate
+// belongs to, independent of how many concrete MessageSet implementations
+// exist for it.
+type MessageType int
+
+const (
+	SignOnMessageType MessageType = iota
+	BankingMessageType
+	CreditCardMessageType
+	InvestmentMessageType
+	SecurityListMessageType
+)
+
+// MessageSet is implemented by every top-level OFX message set aggregate,
+// i.e. the *MSGSRSV1 children of <OFX> such as SIGNONMSGSRSV1 or
+// BANKMSGSRSV1. Implementations are registered with RegisterMessageSet
+// under their OFX tag name so that Document's unmarshaler can dispatch to
+// them generically, letting third parties add tax, bill-pay, or
+// wire-transfer message sets without modifying this package.
+type MessageSet interface {
+	// Name returns the OFX aggregate tag name this message set decodes,
+	// e.g. "BANKMSGSRSV1".
+	Name() string
+	// Type returns the MessageType this message set belongs to.
+	Type() MessageType
+}
+
+// messageSetRegistry maps an OFX message set tag name to a factory that
+// produces a fresh, decodable instance for it.
+var messageSetRegistry = map[string]func() MessageSet{}
+
+// RegisterMessageSet registers a factory for the OFX message set aggregate
+// named by name (e.g. "BANKMSGSRSV1"), so Document's unmarshaler can
+// dispatch to it. It panics if name is already registered, mirroring
+// database/sql.Register.
+func RegisterMessageSet(name string, factory func() MessageSet) {
+	if _, exists := messageSetRegistry[name]; exists {
+		panic(fmt.Sprintf("ofx: MessageSet %q already registered", name))
+	}
+	messageSetRegistry[name] = factory
+}
+
+// SignOnMessageSet models SIGNONMSGSRSV1, the sign-on response every OFX
+// document carries.
+type SignOnMessageSet struct {
+	Response SignOnResponse `xml:"SONRS"`
+}
+
+func (m *SignOnMessageSet) Name() string      { return "SIGNONMSGSRSV1" }
+func (m *SignOnMessageSet) Type() MessageType { return SignOnMessageType }
+
+// BankMessageSet models BANKMSGSRSV1, the checking/savings statement
+// response set.
+type BankMessageSet struct {
+	TRS StatementTransactionResponseSet `xml:"STMTTRNRS"`
+}
+
+func (m *BankMessageSet) Name() string      { return "BANKMSGSRSV1" }
+func (m *BankMessageSet) Type() MessageType { return BankingMessageType }
+
+func init() {
+	RegisterMessageSet("SIGNONMSGSRSV1", func() MessageSet { return &SignOnMessageSet{} })
+	RegisterMessageSet("BANKMSGSRSV1", func() MessageSet { return &BankMessageSet{} })
+}
+
+// Document is a parsed OFX/QFX Statement.
+// This does not implement the complete rfc spec yet.
+type Document struct {
+	XMLName xml.Name `xml:"OFX"`
+	Header  Header   `xml:"-"`
+	// MessageSets holds every top-level message set aggregate found under
+	// <OFX>, keyed by its OFX tag name (e.g. "BANKMSGSRSV1"). Each key maps
+	// to a slice since OFX permits a message set to repeat, even though
+	// most documents include each one at most once.
+	MessageSets map[string][]MessageSet
+}
+
+// UnmarshalXML implements xml.Unmarshaler. It dispatches each direct child
+// of <OFX> to the MessageSet registered for its tag name via
+// RegisterMessageSet, skipping any aggregate this package (or a caller)
+// has not registered a message set for.
+func (d *Document) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	d.MessageSets = map[string][]MessageSet{}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			factory, ok := messageSetRegistry[t.Name.Local]
+			if !ok {
+				if err := decoder.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			messageSet := factory()
+			if err := decoder.DecodeElement(messageSet, &t); err != nil {
+				return fmt.Errorf("ofx: error decoding %s: %w", t.Name.Local, err)
+			}
+			d.MessageSets[t.Name.Local] = append(d.MessageSets[t.Name.Local], messageSet)
+		case xml.EndElement:
+			if t.Name.Local == start.Name.Local {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// SignOn returns the first SIGNONMSGSRSV1 message set in the document, or
+// nil if none was present.
+func (d *Document) SignOn() *SignOnMessageSet {
+	if sets := d.MessageSets["SIGNONMSGSRSV1"]; len(sets) > 0 {
+		if ms, ok := sets[0].(*SignOnMessageSet); ok {
+			return ms
+		}
+	}
+	return nil
+}
+
+// Bank returns the first BANKMSGSRSV1 message set in the document, or nil
+// if none was present.
+func (d *Document) Bank() *BankMessageSet {
+	if sets := d.MessageSets["BANKMSGSRSV1"]; len(sets) > 0 {
+		if ms, ok := sets[0].(*BankMessageSet); ok {
+			return ms
+		}
+	}
+	return nil
+}
+
+// Transactions returns every Transaction found across the document's
+// BANKMSGSRSV1 and CREDITCARDMSGSRSV1 message sets, in the order OFX lists
+// them. It does not include investment transactions, which do not share
+// the Transaction type.
+func (d *Document) Transactions() []Transaction {
+	var txns []Transaction
+	if bank := d.Bank(); bank != nil {
+		txns = append(txns, bank.TRS.RS.Transactions...)
+	}
+	if cc := d.CreditCard(); cc != nil {
+		txns = append(txns, cc.TRS.RS.Transactions...)
+	}
+	return txns
+}
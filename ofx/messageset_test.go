@@ -0,0 +1,130 @@
+package ofx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestRegisterMessageSetPanicsOnDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected RegisterMessageSet to panic on a duplicate name")
+		}
+	}()
+	RegisterMessageSet("BANKMSGSRSV1", func() MessageSet { return &BankMessageSet{} })
+}
+
+func TestDocumentUnmarshalXMLDispatchesKnownMessageSets(t *testing.T) {
+	const body = `<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<DTSERVER>20230101000000</DTSERVER><LANGUAGE>ENG</LANGUAGE>
+<FI><ORG>BIGBANK</ORG><FID>9999</FID></FI>
+</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>1</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS><CURDEF>USD</CURDEF>
+<BANKACCTFROM><BANKID>1</BANKID><ACCTID>2</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST><DTSTART>20230101000000</DTSTART><DTEND>20230131000000</DTEND></BANKTRANLIST>
+<LEDGERBAL><BALAMT>1.00</BALAMT><DTASOF>20230131000000</DTASOF></LEDGERBAL>
+<AVAILBAL><BALAMT>1.00</BALAMT><DTASOF>20230131000000</DTASOF></AVAILBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+	var doc Document
+	if err := xml.Unmarshal([]byte(body), &doc); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if doc.SignOn() == nil {
+		t.Fatal("SignOn() returned nil, want a decoded SignOnMessageSet")
+	}
+	if got := doc.SignOn().Response.Organization; got != "BIGBANK" {
+		t.Errorf("SignOn().Response.Organization = %q, want BIGBANK", got)
+	}
+	if doc.Bank() == nil {
+		t.Fatal("Bank() returned nil, want a decoded BankMessageSet")
+	}
+	if got := doc.Bank().TRS.RS.AccountID; got != "2" {
+		t.Errorf("Bank().TRS.RS.AccountID = %q, want 2", got)
+	}
+	if doc.CreditCard() != nil {
+		t.Error("CreditCard() should be nil when no CREDITCARDMSGSRSV1 is present")
+	}
+}
+
+func TestDocumentUnmarshalXMLSkipsUnregisteredMessageSets(t *testing.T) {
+	const body = `<OFX>
+<TAXMSGSRSV1><TRNUID>1</TRNUID></TAXMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>1</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS><CURDEF>USD</CURDEF>
+<BANKACCTFROM><BANKID>1</BANKID><ACCTID>2</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST><DTSTART>20230101000000</DTSTART><DTEND>20230131000000</DTEND></BANKTRANLIST>
+<LEDGERBAL><BALAMT>1.00</BALAMT><DTASOF>20230131000000</DTASOF></LEDGERBAL>
+<AVAILBAL><BALAMT>1.00</BALAMT><DTASOF>20230131000000</DTASOF></AVAILBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+	var doc Document
+	if err := xml.Unmarshal([]byte(body), &doc); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if _, ok := doc.MessageSets["TAXMSGSRSV1"]; ok {
+		t.Error("unregistered TAXMSGSRSV1 should have been skipped, not recorded")
+	}
+	if doc.Bank() == nil {
+		t.Fatal("Bank() returned nil; the unregistered sibling should not have derailed decoding")
+	}
+}
+
+func TestAccessorsReturnNilWithoutMatchingMessageSet(t *testing.T) {
+	doc := &Document{MessageSets: map[string][]MessageSet{}}
+	if doc.SignOn() != nil {
+		t.Error("SignOn() should be nil on an empty Document")
+	}
+	if doc.Bank() != nil {
+		t.Error("Bank() should be nil on an empty Document")
+	}
+	if doc.CreditCard() != nil {
+		t.Error("CreditCard() should be nil on an empty Document")
+	}
+	if got := doc.Transactions(); len(got) != 0 {
+		t.Errorf("Transactions() = %v, want empty", got)
+	}
+}
+
+func TestDocumentTransactionsConcatenatesBankAndCreditCard(t *testing.T) {
+	const body = `<OFX>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>1</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS><CURDEF>USD</CURDEF>
+<BANKACCTFROM><BANKID>1</BANKID><ACCTID>2</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST><DTSTART>20230101000000</DTSTART><DTEND>20230131000000</DTEND>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20230105000000</DTPOSTED><TRNAMT>-1.00</TRNAMT><FITID>b1</FITID></STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>1.00</BALAMT><DTASOF>20230131000000</DTASOF></LEDGERBAL>
+<AVAILBAL><BALAMT>1.00</BALAMT><DTASOF>20230131000000</DTASOF></AVAILBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><TRNUID>2</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<CCSTMTRS><CURDEF>USD</CURDEF><CCACCTFROM><ACCTID>CC-1</ACCTID></CCACCTFROM>
+<BANKTRANLIST><DTSTART>20230101000000</DTSTART><DTEND>20230131000000</DTEND>
+<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20230110000000</DTPOSTED><TRNAMT>2.00</TRNAMT><FITID>c1</FITID></STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>1.00</BALAMT><DTASOF>20230131000000</DTASOF></LEDGERBAL>
+<AVAILBAL><BALAMT>1.00</BALAMT><DTASOF>20230131000000</DTASOF></AVAILBAL>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>`
+
+	var doc Document
+	if err := xml.Unmarshal([]byte(body), &doc); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	txns := doc.Transactions()
+	if len(txns) != 2 {
+		t.Fatalf("Transactions() returned %d transactions, want 2", len(txns))
+	}
+	if txns[0].ID != "b1" || txns[1].ID != "c1" {
+		var ids []string
+		for _, txn := range txns {
+			ids = append(ids, txn.ID)
+		}
+		t.Errorf("Transactions() order = %s, want [b1 c1] (bank before credit card)", strings.Join(ids, ","))
+	}
+}
@@ -0,0 +1,243 @@
+package ofx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// DefaultMaxDepth is the tag-nesting depth the SGML tag-repair pass
+// tolerates when a Parser does not set MaxDepth. It matches the fixed-size
+// stack capacity this package used before Parser existed.
+const DefaultMaxDepth = 1000
+
+// Logger is the logging interface Parser uses to trace the SGML
+// tag-repair pass. Parser used to hard-code calls to the glog package;
+// any logger, including one backed by glog, can be plugged in by
+// implementing this interface.
+type Logger interface {
+	Infof(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// noopLogger discards everything. It is Parser's default so that parsing
+// does not require configuring a logger.
+type noopLogger struct{}
+
+func (noopLogger) Infof(string, ...interface{})  {}
+func (noopLogger) Errorf(string, ...interface{}) {}
+
+// Parser parses OFX/QFX documents with configurable limits and behavior.
+// The zero value is ready to use and matches the defaults
+// NewDocumentFromXML, NewDocumentFromBytes, and NewDocumentFromReader use.
+type Parser struct {
+	// MaxDepth caps how many tags the SGML tag-repair pass may have open
+	// at once, returning an error instead of panicking on hostile, deeply
+	// nested or unclosed input. Zero selects DefaultMaxDepth.
+	MaxDepth int
+	// MaxTokens caps how many XML tokens a single document may contain.
+	// Zero means unlimited.
+	MaxTokens int
+	// Strict fails parsing on the first recoverable problem (currently, a
+	// malformed header line) instead of logging it and skipping it as the
+	// default lenient mode does.
+	Strict bool
+	// Logger receives diagnostic trace of the parse. Defaults to a no-op
+	// logger.
+	Logger Logger
+	// OnTransaction, if set, is called once for every Transaction found in
+	// the parsed document (across BANKMSGSRSV1 and CREDITCARDMSGSRSV1), so
+	// callers can process transactions as they're produced instead of
+	// walking the returned Document afterwards.
+	OnTransaction func(Transaction) error
+}
+
+func (p *Parser) maxDepth() int {
+	if p.MaxDepth > 0 {
+		return p.MaxDepth
+	}
+	return DefaultMaxDepth
+}
+
+func (p *Parser) logger() Logger {
+	if p.Logger != nil {
+		return p.Logger
+	}
+	return noopLogger{}
+}
+
+// NewDocumentFromReader parses an OFX/QFX document read from r using a
+// Parser with default limits and behavior.
+func NewDocumentFromReader(r io.Reader) (*Document, error) {
+	return (&Parser{}).Parse(r)
+}
+
+// NewDocumentFromXML parses the OFX/QFX document stored in filename.
+func NewDocumentFromXML(filename string) (*Document, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewDocumentFromReader(f)
+}
+
+// NewDocumentFromBytes parses an OFX/QFX document already held in memory,
+// e.g. an HTTP response body from ofx/client.
+func NewDocumentFromBytes(data []byte) (*Document, error) {
+	return NewDocumentFromReader(bytes.NewReader(data))
+}
+
+// Parse parses an OFX/QFX document read in full from r.
+func (p *Parser) Parse(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header, bodyIndex, err := parseHeader(data, p.Strict)
+	if err != nil {
+		return nil, err
+	}
+	charset, err := header.charsetEncoding()
+	if err != nil {
+		return nil, err
+	}
+	body := data[bodyIndex:]
+
+	document := &Document{Header: *header}
+
+	// OFX 2.x bodies are true, well-formed XML and can be unmarshaled
+	// directly. Only OFX/QFX 1.x SGML needs the tag-repair pass below.
+	if header.IsXML() {
+		var reader io.Reader = bytes.NewReader(body)
+		if charset != nil {
+			reader = transform.NewReader(reader, charset.NewDecoder())
+		}
+		if err = xml.NewDecoder(reader).Decode(document); err != nil {
+			return nil, err
+		}
+	} else if err = p.parseSGML(body, charset, document); err != nil {
+		return nil, err
+	}
+
+	if p.OnTransaction != nil {
+		for _, txn := range document.Transactions() {
+			if err := p.OnTransaction(txn); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return document, nil
+}
+
+// parseSGML repairs OFX/QFX 1.x's SGML-derived syntax -- unclosed leaf
+// tags and a missing XML prolog -- into well-formed XML, then unmarshals
+// it into document. charset transcodes the body to UTF-8 first if the
+// header declared a non-UTF-8 CHARSET; it is nil when no transcoding is
+// needed.
+func (p *Parser) parseSGML(body []byte, charset encoding.Encoding, document *Document) error {
+	xmlIndex := bytes.Index(body, []byte("<OFX>"))
+	if xmlIndex == -1 {
+		return fmt.Errorf("error - invalid file, OFX tag not found")
+	}
+	// Start a xml decoder on the context of source data that is XML like,
+	// transcoding it to UTF-8 first if the header declared a non-UTF-8
+	// CHARSET, since encoding/xml rejects CharData that isn't valid UTF-8.
+	var reader io.Reader = bytes.NewReader(body[xmlIndex:])
+	if charset != nil {
+		reader = transform.NewReader(reader, charset.NewDecoder())
+	}
+	decoder := xml.NewDecoder(reader)
+
+	var (
+		tagStack   []*xml.StartElement // A stack to keep parsed tags; grows up to p.maxDepth().
+		lastTagIdx = -1                // Index for the last tag on the stack.
+		endMarker  bool                // flag to indicate an expected but missing closing tag.
+		cleanXML   bytes.Buffer        // Buffer to hold cleaned XML.
+		tokenCount int                 // Number of XML tokens read so far.
+	)
+
+	// Read parsed XML tokens from the XML decoder into token and
+	// re-assemble them into another buffer, while adding any missing
+	// closing tags and trimming spaces/newlines.
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+
+		tokenCount++
+		if p.MaxTokens > 0 && tokenCount > p.MaxTokens {
+			return fmt.Errorf("ofx: exceeded max token count %d", p.MaxTokens)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			p.logger().Infof("case start element %s", t.Name.Local)
+			// Before opening a new element, we check if there is an end marker meaning, we're expecting a
+			// previous tag to be closed first. If so, we close the previous tag first and reset the end marker.
+			if endMarker && lastTagIdx > 0 {
+				p.logger().Infof("end marker is set, closing previous tags.")
+				writeEndTag(tagStack[lastTagIdx].Name, &cleanXML, p.logger())
+				lastTagIdx--
+				endMarker = false
+			}
+			// Write the new tag to clean XML buffer and put it on the tag stack as well.
+			if lastTagIdx+1 >= p.maxDepth() {
+				return fmt.Errorf("ofx: exceeded max tag nesting depth %d", p.maxDepth())
+			}
+			lastTagIdx++
+			if lastTagIdx == len(tagStack) {
+				tagStack = append(tagStack, &t)
+			} else {
+				tagStack[lastTagIdx] = &t
+			}
+			writeStartTag(&t, &cleanXML, p.logger())
+		case xml.CharData:
+			cleanData := escapeString(strings.TrimSpace(string([]byte(t))))
+			p.logger().Infof("case chardata (%s) %#v", cleanData, t)
+			if cleanData == "" {
+				continue
+			}
+			p.logger().Infof("wrote non empty data %s %v", cleanData, t)
+			if _, err = cleanXML.WriteString(cleanData); err != nil {
+				return err
+			}
+			// We set the end marker after we just write chardata to the cleaned xml buffer.
+			// This implies that we are expecting an end tag right after this. This assumes
+			// that a given element will not have both chardata and nested tags. Which means
+			// that if we just saw chardata for the current token, there will not be any nested
+			// elements and we can expect a end element next. If an end element is present in
+			// the source data, the end element case next should reset the endMarker. If the
+			// end element is missing from the source data, the next start element will check
+			// for it and close the offending previous element before starting a new one.
+			endMarker = true
+		case xml.EndElement:
+			p.logger().Infof("case end element %s", t.Name.Local)
+			// Close every open tag till we match the current closing tag.
+			for lastTagIdx > -1 {
+				lastTag := tagStack[lastTagIdx].Name
+				writeEndTag(tagStack[lastTagIdx].Name, &cleanXML, p.logger())
+				lastTagIdx--
+				// If the end element matches the last tag on the stack, pop it off the stack
+				// and reset the end marker, since we have closed that tag.
+				if lastTag.Local == t.Name.Local {
+					endMarker = false
+					break
+				}
+			}
+		}
+	}
+	p.logger().Infof("cleanXML: %s", cleanXML.String())
+	return xml.Unmarshal(cleanXML.Bytes(), document)
+}
@@ -0,0 +1,27 @@
+package ofx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// FuzzParse feeds malformed SGML -- deeply nested tags, unclosed tags, and
+// other hostile shapes -- through Parser.Parse to guard against the stack
+// exhaustion / panic class of bug that motivated MaxDepth and MaxTokens.
+// Parse errors are expected and fine; a panic is not.
+func FuzzParse(f *testing.F) {
+	f.Add([]byte("OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n" +
+		"<OFX><SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS>" +
+		"<DTSERVER>20230101<LANGUAGE>ENG</SONRS></SIGNONMSGSRSV1></OFX>"))
+	f.Add([]byte("OFXHEADER:100\nVERSION:102\n\n<OFX><A><A><A>x</OFX>"))
+	f.Add([]byte("OFXHEADER:100\nVERSION:102\n\n<OFX>" + strings.Repeat("<A>", 5000) + "</OFX>"))
+	f.Add([]byte("OFXHEADER:100\nVERSION:102\n\n<OFX><A>" + strings.Repeat("</A><A>", 5000) + "</A></OFX>"))
+	f.Add([]byte(""))
+	f.Add([]byte("garbage with no OFX tag at all"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := &Parser{MaxDepth: 256, MaxTokens: 100000}
+		_, _ = p.Parse(bytes.NewReader(data))
+	})
+}
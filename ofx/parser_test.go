@@ -0,0 +1,76 @@
+package ofx
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// recordingLogger captures every Infof call so tests can assert on what
+// the SGML tag-repair pass traced.
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Infof(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestParserLoggerTracesEveryTag(t *testing.T) {
+	const body = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:NONE
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX><SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO<DTSERVER>20230101000000<LANGUAGE>ENG</SONRS></SIGNONMSGSRSV1></OFX>`
+
+	logger := &recordingLogger{}
+	p := &Parser{Logger: logger}
+	if _, err := p.Parse(strings.NewReader(body)); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(logger.lines) == 0 {
+		t.Fatal("Logger.Infof was never called; tag push/pop tracing is not wired through")
+	}
+	var sawPushed, sawPopped bool
+	for _, line := range logger.lines {
+		if strings.HasPrefix(line, "pushed: ") {
+			sawPushed = true
+		}
+		if strings.HasPrefix(line, "popped: ") {
+			sawPopped = true
+		}
+	}
+	if !sawPushed || !sawPopped {
+		t.Errorf("expected both pushed/popped tag trace lines via Parser.Logger, got %v", logger.lines)
+	}
+}
+
+func TestParserDefaultLoggerIsNoop(t *testing.T) {
+	const body = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:NONE
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX><SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO<DTSERVER>20230101000000<LANGUAGE>ENG</SONRS></SIGNONMSGSRSV1></OFX>`
+
+	var p Parser
+	if _, err := p.Parse(strings.NewReader(body)); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+}
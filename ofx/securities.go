@@ -0,0 +1,37 @@
+package ofx
+
+// Security is a single security description from SECLIST, e.g. STOCKINFO
+// or MFINFO. Both share the SECINFO base aggregate this models.
+type Security struct {
+	SecurityID   string `xml:"SECINFO>SECID>UNIQUEID"`
+	SecurityType string `xml:"SECINFO>SECID>UNIQUEIDTYPE"`
+	Name         string `xml:"SECINFO>SECNAME"`
+	Ticker       string `xml:"SECINFO>TICKER,omitempty"`
+	UnitPrice    Amount `xml:"SECINFO>UNITPRICE,omitempty"`
+	DateAsOf     Date   `xml:"SECINFO>DTASOF,omitempty"`
+}
+
+// SecurityListMessageSet models SECLISTMSGSRSV1, the security reference
+// list referenced by INVPOSLIST/INVTRANLIST security IDs.
+type SecurityListMessageSet struct {
+	Stocks      []Security `xml:"SECLISTTRNRS>SECLISTRS>SECLIST>STOCKINFO"`
+	MutualFunds []Security `xml:"SECLISTTRNRS>SECLISTRS>SECLIST>MFINFO"`
+}
+
+func (m *SecurityListMessageSet) Name() string      { return "SECLISTMSGSRSV1" }
+func (m *SecurityListMessageSet) Type() MessageType { return SecurityListMessageType }
+
+func init() {
+	RegisterMessageSet("SECLISTMSGSRSV1", func() MessageSet { return &SecurityListMessageSet{} })
+}
+
+// SecurityList returns the first SECLISTMSGSRSV1 message set in the
+// document, or nil if none was present.
+func (d *Document) SecurityList() *SecurityListMessageSet {
+	if sets := d.MessageSets["SECLISTMSGSRSV1"]; len(sets) > 0 {
+		if ms, ok := sets[0].(*SecurityListMessageSet); ok {
+			return ms
+		}
+	}
+	return nil
+}
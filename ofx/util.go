@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"encoding/xml"
 	"unicode/utf8"
-
-	"github.com/golang/glog"
 )
 
 var (
@@ -80,8 +78,8 @@ func escapeString(s string) string {
 
 // writeStartTag writes the given start element to the given buffer.
 // based on https://golang.org/src/encoding/xml/marshal.go:678
-func writeStartTag(e *xml.StartElement, buff *bytes.Buffer) {
-	glog.Infof("pushed: %s", e.Name.Local)
+func writeStartTag(e *xml.StartElement, buff *bytes.Buffer, logger Logger) {
+	logger.Infof("pushed: %s", e.Name.Local)
 	buff.WriteByte('<')
 	buff.WriteString(e.Name.Local)
 	// Namespace
@@ -107,8 +105,8 @@ func writeStartTag(e *xml.StartElement, buff *bytes.Buffer) {
 
 // writeEndTag writes the closing tag for the given end element to the given buffer.
 // based on https://golang.org/src/encoding/xml/marshal.go:717
-func writeEndTag(name xml.Name, buff *bytes.Buffer) {
-	glog.Infof("popped: %s", name.Local)
+func writeEndTag(name xml.Name, buff *bytes.Buffer, logger Logger) {
+	logger.Infof("popped: %s", name.Local)
 	buff.Write([]byte("</"))
 	buff.WriteString(name.Local)
 	buff.WriteByte('>')